@@ -5,34 +5,58 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
 )
 
+// Default timeout/poll settings for the eventual-consistency wait after create/update/delete
+const (
+	defaultPolicyTimeout = 5 * time.Minute
+	policyPollInterval   = 5 * time.Second
+)
+
 // Policy type IDs. These are global and can be listed using the following endpoint:
 //	https://docs.microsoft.com/en-us/rest/api/azure/devops/policy/types/list?view=azure-devops-rest-5.1
 var (
-	NoActiveComments = uuid.MustParse("c6a1889d-b943-4856-b76f-9e46bb6b0df2")
-	MinReviewerCount = uuid.MustParse("fa4e907d-c16b-4a4c-9dfa-4906e5d171dd")
-	SuccessfulBuild  = uuid.MustParse("0609b952-1397-4640-95ec-e00a01b2c241")
+	NoActiveComments  = uuid.MustParse("c6a1889d-b943-4856-b76f-9e46bb6b0df2")
+	MinReviewerCount  = uuid.MustParse("fa4e907d-c16b-4a4c-9dfa-4906e5d171dd")
+	SuccessfulBuild   = uuid.MustParse("0609b952-1397-4640-95ec-e00a01b2c241")
+	RequiredReviewers = uuid.MustParse("fd2167ab-b0be-447a-8ec8-39368250530e")
+	WorkItemLinking   = uuid.MustParse("40e92b44-2fe1-4dd6-b3d8-74a9c21d0c6e")
 )
 
 // Keys for schema elements
 const (
-	SchemaProjectID     = "project_id"
-	SchemaEnabled       = "enabled"
-	SchemaBlocking      = "blocking"
-	SchemaSettings      = "settings"
-	SchemaScope         = "scope"
-	SchemaRepositoryID  = "repository_id"
-	SchemaRepositoryRef = "repository_ref"
-	SchemaMatchType     = "match_type"
+	SchemaProjectID       = "project_id"
+	SchemaEnabled         = "enabled"
+	SchemaBlocking        = "blocking"
+	SchemaSettings        = "settings"
+	SchemaScope           = "scope"
+	SchemaRepositoryID    = "repository_id"
+	SchemaRepositoryRef   = "repository_ref"
+	SchemaMatchType       = "match_type"
+	SchemaEvaluationMode  = "evaluation_mode"
+	SchemaNonCompliantPRs = "non_compliant_pull_requests"
+)
+
+// The action taken against open pull requests in scope when a policy is created or updated.
+// Mirrors the resource_discovery_mode pattern used by azurerm_policy_remediation.
+const (
+	// EvaluationModeNone leaves existing policy evaluations untouched (default).
+	EvaluationModeNone = "None"
+	// EvaluationModeExistingNonCompliant reports open, non-compliant pull requests without requeuing them.
+	EvaluationModeExistingNonCompliant = "ExistingNonCompliant"
+	// EvaluationModeReEvaluateCompliance requeues the policy evaluation for every non-compliant pull request.
+	EvaluationModeReEvaluateCompliance = "ReEvaluateCompliance"
 )
 
 // The type of repository branch name matching strategy used by the policy
@@ -56,6 +80,69 @@ type commonPolicySettings struct {
 	} `json:"scope"`
 }
 
+// PolicyScope describes a single scope entry (repository/ref) within a policy's settings
+type PolicyScope struct {
+	RepositoryID      string
+	RepositoryRefName string
+	MatchType         string
+}
+
+// ScopesFromSettings parses the scope entries out of a policy configuration's raw settings.
+// This is shared with consumers outside of this package, such as the branch policies data
+// source, that need to inspect scope without going through a *schema.ResourceData.
+func ScopesFromSettings(settings interface{}) ([]PolicyScope, error) {
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	policySettings := commonPolicySettings{}
+	if err := json.Unmarshal(raw, &policySettings); err != nil {
+		return nil, err
+	}
+
+	scopes := make([]PolicyScope, len(policySettings.Scopes))
+	for index, scope := range policySettings.Scopes {
+		scopes[index] = PolicyScope{
+			RepositoryID:      scope.RepositoryID,
+			RepositoryRefName: scope.RepositoryRefName,
+			MatchType:         scope.MatchType,
+		}
+	}
+	return scopes, nil
+}
+
+// UnmarshalSettings decodes a policy configuration's raw settings into v. This is used by
+// typed policy resources to pull their type-specific fields out of Settings alongside scope.
+func UnmarshalSettings(settings interface{}, v interface{}) error {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// NormalizeSettingsJSON re-serializes a policy configuration's raw settings with a
+// deterministic (alphabetically sorted) key order, so repeated reads of unchanged
+// server-side settings do not produce spurious diffs.
+func NormalizeSettingsJSON(settings interface{}) (string, error) {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return "", err
+	}
+
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return "", err
+	}
+
+	normalized, err := json.Marshal(raw)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
 // GenBasePolicyResource creates a Resource with the common elements of a build policy
 func GenBasePolicyResource(crudArgs *PolicyCrudArgs) *schema.Resource {
 	return &schema.Resource{
@@ -65,6 +152,11 @@ func GenBasePolicyResource(crudArgs *PolicyCrudArgs) *schema.Resource {
 		Delete:   genPolicyDeleteFunc(crudArgs),
 		Importer: genPolicyImporter(),
 		Schema:   genBaseSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultPolicyTimeout),
+			Update: schema.DefaultTimeout(defaultPolicyTimeout),
+			Delete: schema.DefaultTimeout(defaultPolicyTimeout),
+		},
 	}
 }
 
@@ -79,7 +171,11 @@ func BaseFlattenFunc(d *schema.ResourceData, policyConfig *policy.PolicyConfigur
 
 func flattenSettings(d *schema.ResourceData, policyConfig *policy.PolicyConfiguration) []interface{} {
 	policySettings := commonPolicySettings{}
-	json.Unmarshal([]byte(fmt.Sprintf("%v", policyConfig.Settings)), &policySettings)
+	if err := UnmarshalSettings(policyConfig.Settings, &policySettings); err != nil {
+		// Settings couldn't be parsed; leave the previously-known scope state rather than
+		// clobbering it, since this function has no way to surface the error to the caller.
+		return d.Get(SchemaSettings).([]interface{})
+	}
 
 	scopes := make([]interface{}, len(policySettings.Scopes))
 	for index, scope := range policySettings.Scopes {
@@ -193,6 +289,19 @@ func genBaseSchema() map[string]*schema.Schema {
 			MinItems: 1,
 			MaxItems: 1,
 		},
+		SchemaEvaluationMode: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  EvaluationModeNone,
+			ValidateFunc: validation.StringInSlice([]string{
+				EvaluationModeNone, EvaluationModeExistingNonCompliant, EvaluationModeReEvaluateCompliance,
+			}, false),
+		},
+		SchemaNonCompliantPRs: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
 	}
 }
 
@@ -209,10 +318,18 @@ func genPolicyCreateFunc(crudArgs *PolicyCrudArgs) schema.CreateFunc {
 			Project:       projectID,
 		})
 
-		if err != nil) {
+		if err != nil {
 			return fmt.Errorf("Error creating policy in Azure DevOps: %+v", err)
 		}
 
+		if err := waitForPolicyConfiguration(clients, projectID, createdPolicy, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return fmt.Errorf("Error waiting for policy configuration to be created: %+v", err)
+		}
+
+		if err := applyEvaluationMode(d, clients, projectID, createdPolicy); err != nil {
+			return err
+		}
+
 		crudArgs.FlattenFunc(d, createdPolicy, projectID)
 		return nil
 	}
@@ -265,6 +382,14 @@ func genPolicyUpdateFunc(crudArgs *PolicyCrudArgs) schema.UpdateFunc {
 			return fmt.Errorf("Error updating policy in Azure DevOps: %+v", err)
 		}
 
+		if err := waitForPolicyConfiguration(clients, projectID, updatedPolicy, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("Error waiting for policy configuration to be updated: %+v", err)
+		}
+
+		if err := applyEvaluationMode(d, clients, projectID, updatedPolicy); err != nil {
+			return err
+		}
+
 		crudArgs.FlattenFunc(d, updatedPolicy, projectID)
 		return nil
 	}
@@ -287,10 +412,198 @@ func genPolicyDeleteFunc(crudArgs *PolicyCrudArgs) schema.DeleteFunc {
 			return fmt.Errorf("Error deleting policy in Azure DevOps: %+v", err)
 		}
 
+		if err := waitForPolicyDeletion(clients, projectID, *policyConfig.Id, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmt.Errorf("Error waiting for policy configuration to be deleted: %+v", err)
+		}
+
 		return nil
 	}
 }
 
+// waitForPolicyConfiguration polls GetPolicyConfiguration until the server reflects the
+// desired settings and enabled/blocking flags, since reads immediately after a create/update
+// can return stale data.
+func waitForPolicyConfiguration(clients *config.AggregatedClient, projectID *string, desired *policy.PolicyConfiguration, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"synced"},
+		Refresh: func() (interface{}, string, error) {
+			current, err := clients.PolicyClient.GetPolicyConfiguration(clients.Ctx, policy.GetPolicyConfigurationArgs{
+				Project:         projectID,
+				ConfigurationId: desired.Id,
+			})
+
+			if utils.ResponseWasNotFound(err) {
+				return nil, "pending", nil
+			}
+			if err != nil {
+				return nil, "", err
+			}
+
+			synced, err := policyConfigurationsMatch(desired, current)
+			if err != nil {
+				return nil, "", err
+			}
+			if !synced {
+				return current, "pending", nil
+			}
+
+			return current, "synced", nil
+		},
+		Timeout:      timeout,
+		PollInterval: policyPollInterval,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+// policyConfigurationsMatch reports whether a server-side policy configuration reflects the
+// settings and enabled/blocking flags that were just submitted.
+func policyConfigurationsMatch(desired, current *policy.PolicyConfiguration) (bool, error) {
+	desiredSettings, err := NormalizeSettingsJSON(desired.Settings)
+	if err != nil {
+		return false, err
+	}
+	currentSettings, err := NormalizeSettingsJSON(current.Settings)
+	if err != nil {
+		return false, err
+	}
+
+	if currentSettings != desiredSettings {
+		return false, nil
+	}
+	if converter.ToBool(current.IsEnabled, true) != converter.ToBool(desired.IsEnabled, true) {
+		return false, nil
+	}
+	if converter.ToBool(current.IsBlocking, true) != converter.ToBool(desired.IsBlocking, true) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// waitForPolicyDeletion polls GetPolicyConfiguration until the configuration is gone.
+func waitForPolicyDeletion(clients *config.AggregatedClient, projectID *string, policyID int, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"deleted"},
+		Refresh: func() (interface{}, string, error) {
+			_, err := clients.PolicyClient.GetPolicyConfiguration(clients.Ctx, policy.GetPolicyConfigurationArgs{
+				Project:         projectID,
+				ConfigurationId: &policyID,
+			})
+
+			if utils.ResponseWasNotFound(err) {
+				return "deleted", "deleted", nil
+			}
+			if err != nil {
+				return nil, "", err
+			}
+
+			return "pending", "pending", nil
+		},
+		Timeout:      timeout,
+		PollInterval: policyPollInterval,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+// refMatchesScope reports whether refName falls within a policy scope, honoring the scope's
+// match type: an "Exact" scope must match the ref exactly, while a "Prefix" scope (e.g.
+// "refs/heads/release/") matches any ref beneath it.
+func refMatchesScope(scope PolicyScope, refName string) bool {
+	if scope.RepositoryRefName == "" {
+		return true
+	}
+	if scope.MatchType == matchTypePrefix {
+		return strings.HasPrefix(refName, scope.RepositoryRefName)
+	}
+	return refName == scope.RepositoryRefName
+}
+
+// applyEvaluationMode acts on the resource's evaluation_mode attribute, if set, after a
+// create or update: it looks up open pull requests in the policy's scope, finds the ones
+// that are non-compliant with this policy configuration, and either just reports them
+// (ExistingNonCompliant) or requeues their policy evaluation (ReEvaluateCompliance). This
+// makes rollout of newly-required policies onto a busy repository declarative instead of
+// requiring a manual "requeue" click on every open pull request.
+func applyEvaluationMode(d *schema.ResourceData, clients *config.AggregatedClient, projectID *string, policyConfig *policy.PolicyConfiguration) error {
+	mode := d.Get(SchemaEvaluationMode).(string)
+	if mode == EvaluationModeNone {
+		d.Set(SchemaNonCompliantPRs, []string{})
+		return nil
+	}
+
+	scopes, err := ScopesFromSettings(policyConfig.Settings)
+	if err != nil {
+		return fmt.Errorf("Error parsing policy scope for evaluation: %+v", err)
+	}
+
+	nonCompliant := []string{}
+	for _, scope := range scopes {
+		if scope.RepositoryID == "" {
+			continue
+		}
+
+		// The scope's ref can be an exact branch or a prefix (e.g. "refs/heads/release/"), so the
+		// list of candidate pull requests is fetched per-repository and filtered against the
+		// scope's match type locally, rather than relying on an exact TargetRefName match server-side.
+		pullRequests, err := clients.GitReposClient.GetPullRequests(clients.Ctx, git.GetPullRequestsArgs{
+			RepositoryId: &scope.RepositoryID,
+			Project:      projectID,
+			SearchCriteria: &git.GitPullRequestSearchCriteria{
+				Status: &git.PullRequestStatusValues.Active,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Error listing pull requests for repository (%s): %+v", scope.RepositoryID, err)
+		}
+
+		for _, pr := range *pullRequests {
+			if pr.TargetRefName == nil || !refMatchesScope(scope, *pr.TargetRefName) {
+				continue
+			}
+
+			artifactID := fmt.Sprintf("vstfs:///CodeReview/CodeReviewId/%s/%d", *projectID, *pr.PullRequestId)
+
+			evaluations, err := clients.PolicyClient.GetPolicyEvaluations(clients.Ctx, policy.GetPolicyEvaluationsArgs{
+				Project:    projectID,
+				ArtifactId: &artifactID,
+			})
+			if err != nil {
+				return fmt.Errorf("Error getting policy evaluations for pull request %d: %+v", *pr.PullRequestId, err)
+			}
+
+			for _, evaluation := range *evaluations {
+				if evaluation.Configuration == nil || evaluation.Configuration.Id == nil || *evaluation.Configuration.Id != *policyConfig.Id {
+					continue
+				}
+				if evaluation.Status != nil && *evaluation.Status == policy.PolicyEvaluationStatusValues.Approved {
+					continue
+				}
+
+				prID := strconv.Itoa(*pr.PullRequestId)
+				nonCompliant = append(nonCompliant, prID)
+
+				if mode == EvaluationModeReEvaluateCompliance {
+					_, err := clients.PolicyClient.RequeuePolicyEvaluation(clients.Ctx, policy.RequeuePolicyEvaluationArgs{
+						Project:      projectID,
+						EvaluationId: evaluation.EvaluationId,
+					})
+					if err != nil {
+						return fmt.Errorf("Error requeuing policy evaluation for pull request %d: %+v", *pr.PullRequestId, err)
+					}
+				}
+			}
+		}
+	}
+
+	d.Set(SchemaNonCompliantPRs, nonCompliant)
+	return nil
+}
+
 func genPolicyImporter() *schema.ResourceImporter {
 	return &schema.ResourceImporter{
 		State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
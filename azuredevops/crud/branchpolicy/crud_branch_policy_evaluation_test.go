@@ -0,0 +1,51 @@
+package branchpolicy
+
+import "testing"
+
+func TestRefMatchesScope(t *testing.T) {
+	cases := []struct {
+		name    string
+		scope   PolicyScope
+		refName string
+		want    bool
+	}{
+		{
+			name:    "exact match",
+			scope:   PolicyScope{RepositoryRefName: "refs/heads/main", MatchType: matchTypeExact},
+			refName: "refs/heads/main",
+			want:    true,
+		},
+		{
+			name:    "exact scope does not match a different ref",
+			scope:   PolicyScope{RepositoryRefName: "refs/heads/main", MatchType: matchTypeExact},
+			refName: "refs/heads/release/1.0",
+			want:    false,
+		},
+		{
+			name:    "prefix scope matches a ref beneath it",
+			scope:   PolicyScope{RepositoryRefName: "refs/heads/release/", MatchType: matchTypePrefix},
+			refName: "refs/heads/release/1.0",
+			want:    true,
+		},
+		{
+			name:    "prefix scope does not match an unrelated ref",
+			scope:   PolicyScope{RepositoryRefName: "refs/heads/release/", MatchType: matchTypePrefix},
+			refName: "refs/heads/main",
+			want:    false,
+		},
+		{
+			name:    "empty scope ref matches everything",
+			scope:   PolicyScope{RepositoryRefName: "", MatchType: matchTypeExact},
+			refName: "refs/heads/main",
+			want:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := refMatchesScope(tc.scope, tc.refName); got != tc.want {
+				t.Errorf("refMatchesScope(%+v, %q) = %v, want %v", tc.scope, tc.refName, got, tc.want)
+			}
+		})
+	}
+}
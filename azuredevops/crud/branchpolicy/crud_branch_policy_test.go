@@ -0,0 +1,73 @@
+package branchpolicy
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// decodedSettings mimics how policy.PolicyConfiguration.Settings actually arrives: the SDK
+// decodes the server's JSON response into an interface{} holding a map[string]interface{},
+// not a JSON string.
+func decodedSettings(t *testing.T, raw string) interface{} {
+	t.Helper()
+	var settings interface{}
+	if err := json.Unmarshal([]byte(raw), &settings); err != nil {
+		t.Fatalf("failed to prepare test fixture: %+v", err)
+	}
+	return settings
+}
+
+func TestScopesFromSettings(t *testing.T) {
+	settings := decodedSettings(t, `{
+		"scope": [
+			{"repositoryId": "repo-1", "refName": "refs/heads/main", "matchKind": "Exact"},
+			{"repositoryId": "repo-2", "refName": "refs/heads/release/", "matchKind": "Prefix"}
+		]
+	}`)
+
+	scopes, err := ScopesFromSettings(settings)
+	if err != nil {
+		t.Fatalf("ScopesFromSettings returned an error for a valid, decoded settings payload: %+v", err)
+	}
+	if len(scopes) != 2 {
+		t.Fatalf("expected 2 scopes, got %d", len(scopes))
+	}
+	if scopes[0].RepositoryID != "repo-1" || scopes[0].MatchType != "Exact" {
+		t.Errorf("unexpected first scope: %+v", scopes[0])
+	}
+	if scopes[1].RepositoryID != "repo-2" || scopes[1].MatchType != "Prefix" {
+		t.Errorf("unexpected second scope: %+v", scopes[1])
+	}
+}
+
+func TestNormalizeSettingsJSON(t *testing.T) {
+	settings := decodedSettings(t, `{"minimumApproverCount": 2, "creatorVoteCounts": false}`)
+
+	normalized, err := NormalizeSettingsJSON(settings)
+	if err != nil {
+		t.Fatalf("NormalizeSettingsJSON returned an error for a valid, decoded settings payload: %+v", err)
+	}
+
+	want := `{"creatorVoteCounts":false,"minimumApproverCount":2}`
+	if normalized != want {
+		t.Errorf("got %q, want %q", normalized, want)
+	}
+}
+
+func TestNormalizeSettingsJSONIsDeterministic(t *testing.T) {
+	a := decodedSettings(t, `{"b": 1, "a": 2, "c": 3}`)
+	b := decodedSettings(t, `{"c": 3, "a": 2, "b": 1}`)
+
+	normalizedA, err := NormalizeSettingsJSON(a)
+	if err != nil {
+		t.Fatalf("NormalizeSettingsJSON: %+v", err)
+	}
+	normalizedB, err := NormalizeSettingsJSON(b)
+	if err != nil {
+		t.Fatalf("NormalizeSettingsJSON: %+v", err)
+	}
+
+	if normalizedA != normalizedB {
+		t.Errorf("expected key order to be normalized: %q != %q", normalizedA, normalizedB)
+	}
+}
@@ -0,0 +1,66 @@
+package branchpolicy
+
+import (
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+)
+
+func TestPolicyConfigurationsMatch(t *testing.T) {
+	settings := decodedSettings(t, `{"minimumApproverCount": 2, "creatorVoteCounts": false}`)
+
+	desired := &policy.PolicyConfiguration{
+		IsEnabled:  converter.Bool(true),
+		IsBlocking: converter.Bool(true),
+		Settings:   settings,
+	}
+
+	t.Run("matches identical settings and flags", func(t *testing.T) {
+		current := &policy.PolicyConfiguration{
+			IsEnabled:  converter.Bool(true),
+			IsBlocking: converter.Bool(true),
+			Settings:   decodedSettings(t, `{"creatorVoteCounts": false, "minimumApproverCount": 2}`),
+		}
+
+		matched, err := policyConfigurationsMatch(desired, current)
+		if err != nil {
+			t.Fatalf("policyConfigurationsMatch returned an error for a realistic settings payload: %+v", err)
+		}
+		if !matched {
+			t.Error("expected matching settings/flags to report as synced")
+		}
+	})
+
+	t.Run("reports stale settings as not yet synced", func(t *testing.T) {
+		current := &policy.PolicyConfiguration{
+			IsEnabled:  converter.Bool(true),
+			IsBlocking: converter.Bool(true),
+			Settings:   decodedSettings(t, `{"creatorVoteCounts": false, "minimumApproverCount": 1}`),
+		}
+
+		matched, err := policyConfigurationsMatch(desired, current)
+		if err != nil {
+			t.Fatalf("policyConfigurationsMatch: %+v", err)
+		}
+		if matched {
+			t.Error("expected differing settings to report as not synced")
+		}
+	})
+
+	t.Run("reports stale IsBlocking as not yet synced", func(t *testing.T) {
+		current := &policy.PolicyConfiguration{
+			IsEnabled:  converter.Bool(true),
+			IsBlocking: converter.Bool(false),
+			Settings:   decodedSettings(t, `{"creatorVoteCounts": false, "minimumApproverCount": 2}`),
+		}
+
+		matched, err := policyConfigurationsMatch(desired, current)
+		if err != nil {
+			t.Fatalf("policyConfigurationsMatch: %+v", err)
+		}
+		if matched {
+			t.Error("expected differing IsBlocking to report as not synced")
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+)
+
+// dataAgentPool schema and implementation for the azuredevops_agent_pool data source
+func dataAgentPool() *schema.Resource {
+	return &schema.Resource{
+		Read: dataAgentPoolRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"pool_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"auto_provision": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataAgentPoolRead(d *schema.ResourceData, meta interface{}) error {
+	clients := meta.(*config.AggregatedClient)
+	name := d.Get("name").(string)
+
+	pools, err := clients.TaskAgentClient.GetAgentPools(clients.Ctx, taskagent.GetAgentPoolsArgs{
+		PoolName: &name,
+	})
+	if err != nil {
+		return fmt.Errorf("Error looking up agent pool with name %q: %+v", name, err)
+	}
+
+	if pools == nil || len(*pools) == 0 {
+		return fmt.Errorf("Could not find an agent pool with name %q", name)
+	}
+	if len(*pools) > 1 {
+		return fmt.Errorf("Found more than one agent pool with name %q", name)
+	}
+
+	pool := (*pools)[0]
+	d.SetId(strconv.Itoa(*pool.Id))
+	d.Set("name", converter.ToString(pool.Name, ""))
+	d.Set("pool_type", pool.PoolType)
+	d.Set("auto_provision", pool.AutoProvision)
+	return nil
+}
@@ -0,0 +1,88 @@
+package azuredevops
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+)
+
+// dataAgentPools schema and implementation for the azuredevops_agent_pools data source
+func dataAgentPools() *schema.Resource {
+	return &schema.Resource{
+		Read: dataAgentPoolsRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"pools": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pool_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"auto_provision": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataAgentPoolsRead(d *schema.ResourceData, meta interface{}) error {
+	clients := meta.(*config.AggregatedClient)
+
+	allPools, err := clients.TaskAgentClient.GetAgentPools(clients.Ctx, taskagent.GetAgentPoolsArgs{})
+	if err != nil {
+		return fmt.Errorf("Error listing agent pools: %+v", err)
+	}
+
+	var nameFilter *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameFilter, err = regexp.Compile(v.(string))
+		if err != nil {
+			return fmt.Errorf("Error compiling name_regex: %+v", err)
+		}
+	}
+
+	pools := make([]interface{}, 0)
+	if allPools != nil {
+		for _, pool := range *allPools {
+			name := converter.ToString(pool.Name, "")
+			if nameFilter != nil && !nameFilter.MatchString(name) {
+				continue
+			}
+
+			pools = append(pools, map[string]interface{}{
+				"id":             *pool.Id,
+				"name":           name,
+				"pool_type":      pool.PoolType,
+				"auto_provision": converter.ToBool(pool.AutoProvision, false),
+			})
+		}
+	}
+
+	d.SetId("agent-pools")
+	d.Set("pools", pools)
+	return nil
+}
@@ -0,0 +1,155 @@
+package azuredevops
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/branchpolicy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+)
+
+// dataBranchPolicies schema and implementation for the azuredevops_branch_policies data source
+func dataBranchPolicies() *schema.Resource {
+	return &schema.Resource{
+		Read: dataBranchPoliciesRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"policy_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"repository_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ref_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"policies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"policy_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_blocking": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"repository_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ref_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"settings_json": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataBranchPoliciesRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	policyTypeFilter, filterByType := d.GetOk("policy_type")
+	repositoryFilter, filterByRepository := d.GetOk("repository_id")
+	refFilter, filterByRef := d.GetOk("ref_name")
+
+	configs, err := getAllPolicyConfigurations(clients, projectID)
+	if err != nil {
+		return fmt.Errorf("Error listing branch policies for project (%s): %+v", projectID, err)
+	}
+
+	policies := make([]interface{}, 0, len(configs))
+	for _, policyConfig := range configs {
+		if filterByType && policyConfig.Type.Id.String() != policyTypeFilter.(string) {
+			continue
+		}
+
+		scopes, err := branchpolicy.ScopesFromSettings(policyConfig.Settings)
+		if err != nil {
+			return fmt.Errorf("Error parsing settings for policy configuration (%d): %+v", *policyConfig.Id, err)
+		}
+
+		for _, scope := range scopes {
+			if filterByRepository && scope.RepositoryID != repositoryFilter.(string) {
+				continue
+			}
+			if filterByRef && scope.RepositoryRefName != refFilter.(string) {
+				continue
+			}
+
+			settingsJSON, err := branchpolicy.NormalizeSettingsJSON(policyConfig.Settings)
+			if err != nil {
+				return fmt.Errorf("Error serializing settings for policy configuration (%d): %+v", *policyConfig.Id, err)
+			}
+
+			policies = append(policies, map[string]interface{}{
+				"id":            *policyConfig.Id,
+				"policy_type":   policyConfig.Type.Id.String(),
+				"is_enabled":    converter.ToBool(policyConfig.IsEnabled, true),
+				"is_blocking":   converter.ToBool(policyConfig.IsBlocking, true),
+				"repository_id": scope.RepositoryID,
+				"ref_name":      scope.RepositoryRefName,
+				"settings_json": settingsJSON,
+			})
+		}
+	}
+
+	d.SetId(projectID)
+	d.Set("policies", policies)
+	return nil
+}
+
+// getAllPolicyConfigurations pages through PolicyClient.GetPolicyConfigurations, following
+// continuation tokens until the server reports no more pages.
+func getAllPolicyConfigurations(clients *config.AggregatedClient, projectID string) ([]policy.PolicyConfiguration, error) {
+	allConfigs := []policy.PolicyConfiguration{}
+	continuationToken := ""
+
+	for {
+		args := policy.GetPolicyConfigurationsArgs{
+			Project: &projectID,
+		}
+		if continuationToken != "" {
+			args.ContinuationToken = &continuationToken
+		}
+
+		response, err := clients.PolicyClient.GetPolicyConfigurations(clients.Ctx, args)
+		if err != nil {
+			return nil, err
+		}
+
+		allConfigs = append(allConfigs, response.Value...)
+
+		if response.ContinuationToken == "" {
+			break
+		}
+		continuationToken = response.ContinuationToken
+	}
+
+	return allConfigs, nil
+}
@@ -0,0 +1,328 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+// resourceAzureAgentPoolAgents manages the set of self-hosted agents registered to an agent pool
+func resourceAzureAgentPoolAgents() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAzureAgentPoolAgentsCreate,
+		Read:   resourceAzureAgentPoolAgentsRead,
+		Update: resourceAzureAgentPoolAgentsUpdate,
+		Delete: resourceAzureAgentPoolAgentsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAzureAgentPoolAgentsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"pool_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+			"agent": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"capabilities": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAzureAgentPoolAgentsCreate(d *schema.ResourceData, meta interface{}) error {
+	clients := meta.(*config.AggregatedClient)
+	poolID, err := strconv.Atoi(d.Get("pool_id").(string))
+	if err != nil {
+		return fmt.Errorf("Error parsing pool_id: %+v", err)
+	}
+
+	agents := d.Get("agent").(*schema.Set).List()
+	for _, agent := range agents {
+		if err := addAgentToPool(clients, poolID, agent.(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(strconv.Itoa(poolID))
+	return resourceAzureAgentPoolAgentsRead(d, meta)
+}
+
+func resourceAzureAgentPoolAgentsRead(d *schema.ResourceData, meta interface{}) error {
+	clients := meta.(*config.AggregatedClient)
+	poolID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing agent pool ID: %+v", err)
+	}
+
+	allAgents, err := fetchPoolAgents(clients, poolID)
+	if err != nil {
+		return err
+	}
+
+	// Only the agents already tracked in config/prior state are managed by this resource;
+	// anything else registered to the pool (by another resource, the CLI, or a human) is left
+	// alone rather than adopted into state, where it would then look "removed" on the next
+	// plan and get deleted. (Import populates "agent" with every real agent up front, via
+	// resourceAzureAgentPoolAgentsImport, so this filter doesn't drop anything there.)
+	trackedNames := agentsByName(d.Get("agent").(*schema.Set).List())
+
+	agentList := make([]interface{}, 0, len(allAgents))
+	for _, agent := range allAgents {
+		agentMap := agent.(map[string]interface{})
+		if _, tracked := trackedNames[agentMap["name"].(string)]; tracked {
+			agentList = append(agentList, agentMap)
+		}
+	}
+
+	d.Set("pool_id", strconv.Itoa(poolID))
+	d.Set("agent", agentList)
+	return nil
+}
+
+func resourceAzureAgentPoolAgentsImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	clients := meta.(*config.AggregatedClient)
+	poolID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing agent pool ID: %+v", err)
+	}
+
+	// Unlike a normal Read, import has no prior state to reconcile against, so it adopts every
+	// agent currently registered to the pool. The subsequent Read call SDK v1 makes after import
+	// then sees all of them already tracked and keeps them.
+	agentList, err := fetchPoolAgents(clients, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("pool_id", strconv.Itoa(poolID))
+	d.Set("agent", agentList)
+	return []*schema.ResourceData{d}, nil
+}
+
+func fetchPoolAgents(clients *config.AggregatedClient, poolID int) ([]interface{}, error) {
+	agents, err := clients.TaskAgentClient.GetAgents(clients.Ctx, taskagent.GetAgentsArgs{
+		PoolId:              &poolID,
+		IncludeCapabilities: converter.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error looking up agents for pool %d: %+v", poolID, err)
+	}
+
+	agentList := make([]interface{}, 0)
+	if agents != nil {
+		for _, agent := range *agents {
+			capabilities := map[string]interface{}{}
+			if agent.UserCapabilities != nil {
+				for key, value := range *agent.UserCapabilities {
+					capabilities[key] = value
+				}
+			}
+			agentList = append(agentList, map[string]interface{}{
+				"name":         converter.ToString(agent.Name, ""),
+				"enabled":      converter.ToBool(agent.Enabled, true),
+				"capabilities": capabilities,
+			})
+		}
+	}
+	return agentList, nil
+}
+
+func resourceAzureAgentPoolAgentsUpdate(d *schema.ResourceData, meta interface{}) error {
+	clients := meta.(*config.AggregatedClient)
+	poolID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing agent pool ID: %+v", err)
+	}
+
+	oldValue, newValue := d.GetChange("agent")
+	oldAgents := agentsByName(oldValue.(*schema.Set).List())
+	newAgents := agentsByName(newValue.(*schema.Set).List())
+
+	agentIDs, err := agentIDsByName(clients, poolID)
+	if err != nil {
+		return err
+	}
+
+	for name, oldAgent := range oldAgents {
+		if _, stillPresent := newAgents[name]; !stillPresent {
+			if err := deleteAgentFromPool(clients, poolID, agentIDs, oldAgent); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, newAgent := range newAgents {
+		if oldAgent, existed := oldAgents[name]; !existed {
+			if err := addAgentToPool(clients, poolID, newAgent); err != nil {
+				return err
+			}
+		} else if !capabilitiesEqual(oldAgent, newAgent) || oldAgent["enabled"] != newAgent["enabled"] {
+			if err := updateAgentInPool(clients, poolID, agentIDs, newAgent); err != nil {
+				return err
+			}
+		}
+	}
+
+	return resourceAzureAgentPoolAgentsRead(d, meta)
+}
+
+func resourceAzureAgentPoolAgentsDelete(d *schema.ResourceData, meta interface{}) error {
+	clients := meta.(*config.AggregatedClient)
+	poolID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing agent pool ID: %+v", err)
+	}
+
+	agentIDs, err := agentIDsByName(clients, poolID)
+	if err != nil {
+		return err
+	}
+
+	agents := d.Get("agent").(*schema.Set).List()
+	for _, agent := range agents {
+		if err := deleteAgentFromPool(clients, poolID, agentIDs, agent.(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addAgentToPool(clients *config.AggregatedClient, poolID int, agent map[string]interface{}) error {
+	name := agent["name"].(string)
+	enabled := agent["enabled"].(bool)
+
+	_, err := clients.TaskAgentClient.AddAgent(clients.Ctx, taskagent.AddAgentArgs{
+		PoolId: &poolID,
+		Agent: &taskagent.TaskAgent{
+			Name:            converter.String(name),
+			Enabled:         converter.Bool(enabled),
+			UserCapabilities: expandAgentCapabilities(agent),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Error adding agent %q to pool %d: %+v", name, poolID, err)
+	}
+	return nil
+}
+
+func updateAgentInPool(clients *config.AggregatedClient, poolID int, agentIDs map[string]int, newAgent map[string]interface{}) error {
+	name := newAgent["name"].(string)
+	agentID, ok := agentIDs[name]
+	if !ok {
+		return fmt.Errorf("Agent %q not found in pool %d", name, poolID)
+	}
+	enabled := newAgent["enabled"].(bool)
+
+	_, err := clients.TaskAgentClient.UpdateAgent(clients.Ctx, taskagent.UpdateAgentArgs{
+		PoolId:  &poolID,
+		AgentId: &agentID,
+		Agent: &taskagent.TaskAgent{
+			Id:              &agentID,
+			Name:            converter.String(name),
+			Enabled:         converter.Bool(enabled),
+			UserCapabilities: expandAgentCapabilities(newAgent),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating agent %d in pool %d: %+v", agentID, poolID, err)
+	}
+	return nil
+}
+
+func deleteAgentFromPool(clients *config.AggregatedClient, poolID int, agentIDs map[string]int, agent map[string]interface{}) error {
+	name := agent["name"].(string)
+	agentID, ok := agentIDs[name]
+	if !ok {
+		return fmt.Errorf("Agent %q not found in pool %d", name, poolID)
+	}
+
+	if err := clients.TaskAgentClient.DeleteAgent(clients.Ctx, taskagent.DeleteAgentArgs{
+		PoolId:  &poolID,
+		AgentId: &agentID,
+	}); err != nil {
+		return fmt.Errorf("Error removing agent %d from pool %d: %+v", agentID, poolID, err)
+	}
+	return nil
+}
+
+// agentIDsByName resolves the server-assigned IDs of every agent in a pool, keyed by name, in a
+// single API call. Agents are tracked purely by name in configuration (not in this resource's
+// schema) because the ID is assigned by the server and isn't known until after the agent is
+// registered; resolving IDs here at operation time avoids storing a Computed field inside the
+// "agent" TypeSet, which would change an element's hash between plan and apply and surface as a
+// perpetual diff.
+func agentIDsByName(clients *config.AggregatedClient, poolID int) (map[string]int, error) {
+	agents, err := clients.TaskAgentClient.GetAgents(clients.Ctx, taskagent.GetAgentsArgs{
+		PoolId: &poolID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error looking up agents for pool %d: %+v", poolID, err)
+	}
+
+	ids := map[string]int{}
+	if agents != nil {
+		for _, agent := range *agents {
+			ids[converter.ToString(agent.Name, "")] = *agent.Id
+		}
+	}
+	return ids, nil
+}
+
+func expandAgentCapabilities(agent map[string]interface{}) *map[string]string {
+	capabilities := map[string]string{}
+	if raw, ok := agent["capabilities"].(map[string]interface{}); ok {
+		for key, value := range raw {
+			capabilities[key] = value.(string)
+		}
+	}
+	return &capabilities
+}
+
+func agentsByName(agents []interface{}) map[string]map[string]interface{} {
+	byName := map[string]map[string]interface{}{}
+	for _, agent := range agents {
+		agentMap := agent.(map[string]interface{})
+		byName[agentMap["name"].(string)] = agentMap
+	}
+	return byName
+}
+
+func capabilitiesEqual(oldAgent, newAgent map[string]interface{}) bool {
+	oldCaps, _ := oldAgent["capabilities"].(map[string]interface{})
+	newCaps, _ := newAgent["capabilities"].(map[string]interface{})
+	if len(oldCaps) != len(newCaps) {
+		return false
+	}
+	for key, value := range oldCaps {
+		if newCaps[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package azuredevops
+
+import "testing"
+
+func TestAgentsByName(t *testing.T) {
+	agents := []interface{}{
+		map[string]interface{}{"name": "agent-1", "enabled": true},
+		map[string]interface{}{"name": "agent-2", "enabled": false},
+	}
+
+	byName := agentsByName(agents)
+
+	if len(byName) != 2 {
+		t.Fatalf("expected 2 agents, got %d", len(byName))
+	}
+	if byName["agent-1"]["enabled"] != true {
+		t.Errorf("agent-1 enabled = %v, want true", byName["agent-1"]["enabled"])
+	}
+	if byName["agent-2"]["enabled"] != false {
+		t.Errorf("agent-2 enabled = %v, want false", byName["agent-2"]["enabled"])
+	}
+}
+
+func TestCapabilitiesEqual(t *testing.T) {
+	cases := []struct {
+		name     string
+		oldAgent map[string]interface{}
+		newAgent map[string]interface{}
+		want     bool
+	}{
+		{
+			name:     "both empty",
+			oldAgent: map[string]interface{}{},
+			newAgent: map[string]interface{}{},
+			want:     true,
+		},
+		{
+			name:     "identical capabilities",
+			oldAgent: map[string]interface{}{"capabilities": map[string]interface{}{"os": "linux"}},
+			newAgent: map[string]interface{}{"capabilities": map[string]interface{}{"os": "linux"}},
+			want:     true,
+		},
+		{
+			name:     "differing values",
+			oldAgent: map[string]interface{}{"capabilities": map[string]interface{}{"os": "linux"}},
+			newAgent: map[string]interface{}{"capabilities": map[string]interface{}{"os": "windows"}},
+			want:     false,
+		},
+		{
+			name:     "differing key counts",
+			oldAgent: map[string]interface{}{"capabilities": map[string]interface{}{"os": "linux"}},
+			newAgent: map[string]interface{}{"capabilities": map[string]interface{}{"os": "linux", "arch": "amd64"}},
+			want:     false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := capabilitiesEqual(tc.oldAgent, tc.newAgent); got != tc.want {
+				t.Errorf("capabilitiesEqual() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
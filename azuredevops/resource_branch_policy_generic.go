@@ -0,0 +1,144 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/branchpolicy"
+)
+
+// Schema keys specific to the generic branch policy resource
+const (
+	schemaPolicyType   = "policy_type"
+	schemaSettingsJSON = "settings_json"
+)
+
+// resourceBranchPolicyGeneric schema and implementation for a branch policy resource that
+// accepts an arbitrary policy type UUID and a raw JSON settings payload. This allows any
+// policy type exposed by the AzDO Policy Types REST endpoint to be configured without a
+// dedicated, typed resource.
+func resourceBranchPolicyGeneric() *schema.Resource {
+	resource := branchpolicy.GenBasePolicyResource(&branchpolicy.PolicyCrudArgs{
+		FlattenFunc: genericFlattenFunc,
+		ExpandFunc:  genericExpandFunc,
+		PolicyType:  uuid.Nil,
+	})
+
+	resource.Schema[schemaPolicyType] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validatePolicyTypeID,
+	}
+
+	settingsResource := resource.Schema[branchpolicy.SchemaSettings].Elem.(*schema.Resource)
+	settingsResource.Schema[schemaSettingsJSON] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateFunc:     validateSettingsJSON,
+		DiffSuppressFunc: structure.SuppressJsonDiff,
+		StateFunc: func(v interface{}) string {
+			normalized, _ := structure.NormalizeJsonString(v)
+			return normalized
+		},
+	}
+
+	return resource
+}
+
+func validatePolicyTypeID(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return warnings, errors
+	}
+	if _, err := uuid.Parse(v); err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid UUID: %+v", k, err))
+	}
+	return warnings, errors
+}
+
+// validateSettingsJSON ensures settings_json is well-formed JSON that does not declare its own
+// top-level "scope" key. The scope is owned exclusively by the dedicated scope block; allowing
+// settings_json to carry one too would let it silently clobber the scope genericExpandFunc
+// computes from that block.
+func validateSettingsJSON(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return warnings, errors
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(v), &parsed); err != nil {
+		errors = append(errors, fmt.Errorf("%q contains invalid JSON: %+v", k, err))
+		return warnings, errors
+	}
+
+	if _, hasScope := parsed[branchpolicy.SchemaScope]; hasScope {
+		errors = append(errors, fmt.Errorf("%q must not set a top-level %q key; configure the scope block instead", k, branchpolicy.SchemaScope))
+	}
+
+	return warnings, errors
+}
+
+// genericExpandFunc expands the policy_type and settings_json attributes, merging the
+// user-supplied JSON settings with the standard scope block computed by the base resource.
+func genericExpandFunc(d *schema.ResourceData, typeID uuid.UUID) (*policy.PolicyConfiguration, *string, error) {
+	policyTypeID, err := uuid.Parse(d.Get(schemaPolicyType).(string))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error parsing policy type ID: %+v", err)
+	}
+
+	policyConfig, projectID, err := branchpolicy.BaseExpandFunc(d, policyTypeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settingsJSON := d.Get(fmt.Sprintf("%s.0.%s", branchpolicy.SchemaSettings, schemaSettingsJSON)).(string)
+	var extraSettings map[string]interface{}
+	if err := json.Unmarshal([]byte(settingsJSON), &extraSettings); err != nil {
+		return nil, nil, fmt.Errorf("Error parsing settings_json: %+v", err)
+	}
+	if _, hasScope := extraSettings[branchpolicy.SchemaScope]; hasScope {
+		return nil, nil, fmt.Errorf("settings_json must not set a top-level %q key; configure the scope block instead", branchpolicy.SchemaScope)
+	}
+
+	baseSettings := policyConfig.Settings.(map[string]interface{})
+	for key, value := range extraSettings {
+		baseSettings[key] = value
+	}
+	policyConfig.Settings = baseSettings
+
+	return policyConfig, projectID, nil
+}
+
+// genericFlattenFunc flattens the base scope block and re-serializes the remaining,
+// server-side settings deterministically into settings_json.
+func genericFlattenFunc(d *schema.ResourceData, policyConfig *policy.PolicyConfiguration, projectID *string) {
+	branchpolicy.BaseFlattenFunc(d, policyConfig, projectID)
+
+	rawSettings := map[string]interface{}{}
+	if err := branchpolicy.UnmarshalSettings(policyConfig.Settings, &rawSettings); err != nil {
+		// Settings couldn't be parsed; leave the previously-known settings_json in state
+		// rather than clobbering it, since FlattenFunc has no way to surface this error.
+		return
+	}
+	delete(rawSettings, branchpolicy.SchemaScope)
+
+	normalized, err := branchpolicy.NormalizeSettingsJSON(rawSettings)
+	if err != nil {
+		return
+	}
+
+	settings := d.Get(branchpolicy.SchemaSettings).([]interface{})
+	settingsMap := settings[0].(map[string]interface{})
+	settingsMap[schemaSettingsJSON] = normalized
+	d.Set(branchpolicy.SchemaSettings, settings)
+
+	d.Set(schemaPolicyType, policyConfig.Type.Id.String())
+}
@@ -0,0 +1,76 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/branchpolicy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+)
+
+func TestGenericFlattenFunc(t *testing.T) {
+	resource := resourceBranchPolicyGeneric()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+
+	policyID := 7
+	settings := map[string]interface{}{
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": "repo-1",
+				"refName":      "refs/heads/main",
+				"matchKind":    "Exact",
+			},
+		},
+		"minimumApproverCount": float64(1),
+	}
+
+	policyTypeID := uuid.New()
+	policyConfig := &policy.PolicyConfiguration{
+		Id:         &policyID,
+		IsEnabled:  converter.Bool(true),
+		IsBlocking: converter.Bool(true),
+		Settings:   settings,
+		Type:       &policy.PolicyTypeRef{Id: &policyTypeID},
+	}
+	projectID := "project-1"
+
+	genericFlattenFunc(d, policyConfig, &projectID)
+
+	settingsList := d.Get(branchpolicy.SchemaSettings).([]interface{})
+	if len(settingsList) != 1 {
+		t.Fatalf("expected a single settings block, got %d", len(settingsList))
+	}
+	settingsMap := settingsList[0].(map[string]interface{})
+
+	got := settingsMap[schemaSettingsJSON]
+	want := `{"minimumApproverCount":1}`
+	if got != want {
+		t.Errorf("settings_json = %q, want %q (scope key must be excluded)", got, want)
+	}
+}
+
+func TestValidateSettingsJSON(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid settings", value: `{"minimumApproverCount": 1}`, wantErr: false},
+		{name: "invalid JSON", value: `not json`, wantErr: true},
+		{name: "top-level scope key rejected", value: `{"scope": [], "minimumApproverCount": 1}`, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errs := validateSettingsJSON(tc.value, schemaSettingsJSON)
+			if tc.wantErr && len(errs) == 0 {
+				t.Error("expected an error, got none")
+			}
+			if !tc.wantErr && len(errs) != 0 {
+				t.Errorf("expected no error, got %+v", errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package azuredevops
+
+import (
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/branchpolicy"
+)
+
+// Schema keys specific to the required reviewers policy settings
+const (
+	schemaRequiredReviewerIDs   = "required_reviewer_ids"
+	schemaMinimumApproverCount  = "minimum_approver_count"
+	schemaCreatorVoteCounts     = "creator_vote_counts"
+	schemaRequiredReviewerMsg   = "message"
+	schemaRequiredReviewerPaths = "path_filters"
+)
+
+// resourceBranchPolicyRequiredReviewers schema and implementation for the "Required Reviewers" branch policy
+func resourceBranchPolicyRequiredReviewers() *schema.Resource {
+	resource := branchpolicy.GenBasePolicyResource(&branchpolicy.PolicyCrudArgs{
+		FlattenFunc: requiredReviewersFlattenFunc,
+		ExpandFunc:  requiredReviewersExpandFunc,
+		PolicyType:  branchpolicy.RequiredReviewers,
+	})
+
+	settingsSchema := resource.Schema[branchpolicy.SchemaSettings].Elem.(*schema.Resource).Schema
+	settingsSchema[schemaRequiredReviewerIDs] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Required: true,
+		MinItems: 1,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	settingsSchema[schemaMinimumApproverCount] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Required: true,
+	}
+	settingsSchema[schemaCreatorVoteCounts] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	settingsSchema[schemaRequiredReviewerMsg] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	settingsSchema[schemaRequiredReviewerPaths] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+
+	return resource
+}
+
+func requiredReviewersExpandFunc(d *schema.ResourceData, typeID uuid.UUID) (*policy.PolicyConfiguration, *string, error) {
+	policyConfig, projectID, err := branchpolicy.BaseExpandFunc(d, typeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settingsList := d.Get(branchpolicy.SchemaSettings).([]interface{})
+	settingsMap := settingsList[0].(map[string]interface{})
+
+	settings := policyConfig.Settings.(map[string]interface{})
+	settings["requiredReviewerIds"] = settingsMap[schemaRequiredReviewerIDs].(*schema.Set).List()
+	settings["minimumApproverCount"] = settingsMap[schemaMinimumApproverCount].(int)
+	settings["creatorVoteCounts"] = settingsMap[schemaCreatorVoteCounts].(bool)
+	settings["message"] = settingsMap[schemaRequiredReviewerMsg].(string)
+	settings["filenamePatterns"] = settingsMap[schemaRequiredReviewerPaths].(*schema.Set).List()
+	policyConfig.Settings = settings
+
+	return policyConfig, projectID, nil
+}
+
+func requiredReviewersFlattenFunc(d *schema.ResourceData, policyConfig *policy.PolicyConfiguration, projectID *string) {
+	branchpolicy.BaseFlattenFunc(d, policyConfig, projectID)
+
+	typeSettings := struct {
+		RequiredReviewerIDs  []string `json:"requiredReviewerIds"`
+		MinimumApproverCount int      `json:"minimumApproverCount"`
+		CreatorVoteCounts    bool     `json:"creatorVoteCounts"`
+		Message              string   `json:"message"`
+		FilenamePatterns     []string `json:"filenamePatterns"`
+	}{}
+	if err := branchpolicy.UnmarshalSettings(policyConfig.Settings, &typeSettings); err != nil {
+		// Settings couldn't be parsed; leave the previously-known values in state rather than
+		// zeroing them out, since FlattenFunc has no way to surface this error to the caller.
+		return
+	}
+
+	settings := d.Get(branchpolicy.SchemaSettings).([]interface{})
+	settingsMap := settings[0].(map[string]interface{})
+	settingsMap[schemaRequiredReviewerIDs] = typeSettings.RequiredReviewerIDs
+	settingsMap[schemaMinimumApproverCount] = typeSettings.MinimumApproverCount
+	settingsMap[schemaCreatorVoteCounts] = typeSettings.CreatorVoteCounts
+	settingsMap[schemaRequiredReviewerMsg] = typeSettings.Message
+	settingsMap[schemaRequiredReviewerPaths] = typeSettings.FilenamePatterns
+	d.Set(branchpolicy.SchemaSettings, settings)
+}
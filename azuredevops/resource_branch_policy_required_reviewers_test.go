@@ -0,0 +1,57 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/branchpolicy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+)
+
+func TestRequiredReviewersFlattenFunc(t *testing.T) {
+	resource := resourceBranchPolicyRequiredReviewers()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+
+	policyID := 42
+	settings := map[string]interface{}{
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": "repo-1",
+				"refName":      "refs/heads/main",
+				"matchKind":    "Exact",
+			},
+		},
+		"requiredReviewerIds":  []interface{}{"00000000-0000-0000-0000-000000000001"},
+		"minimumApproverCount": float64(2),
+		"creatorVoteCounts":    true,
+		"message":              "please get a second pair of eyes",
+		"filenamePatterns":     []interface{}{"/src/*"},
+	}
+
+	policyConfig := &policy.PolicyConfiguration{
+		Id:         &policyID,
+		IsEnabled:  converter.Bool(true),
+		IsBlocking: converter.Bool(true),
+		Settings:   settings,
+	}
+	projectID := "project-1"
+
+	requiredReviewersFlattenFunc(d, policyConfig, &projectID)
+
+	settingsList := d.Get(branchpolicy.SchemaSettings).([]interface{})
+	if len(settingsList) != 1 {
+		t.Fatalf("expected a single settings block, got %d", len(settingsList))
+	}
+	settingsMap := settingsList[0].(map[string]interface{})
+
+	if got := settingsMap[schemaMinimumApproverCount]; got != 2 {
+		t.Errorf("minimum_approver_count = %v, want 2", got)
+	}
+	if got := settingsMap[schemaCreatorVoteCounts]; got != true {
+		t.Errorf("creator_vote_counts = %v, want true", got)
+	}
+	if got := settingsMap[schemaRequiredReviewerMsg]; got != "please get a second pair of eyes" {
+		t.Errorf("message = %v, want the configured message", got)
+	}
+}
@@ -0,0 +1,26 @@
+package azuredevops
+
+import (
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/branchpolicy"
+)
+
+// resourceBranchPolicyWorkItemLinking schema and implementation for the "Work Item Linking" branch policy.
+// The policy has no settings beyond the standard scope block.
+func resourceBranchPolicyWorkItemLinking() *schema.Resource {
+	return branchpolicy.GenBasePolicyResource(&branchpolicy.PolicyCrudArgs{
+		FlattenFunc: workItemLinkingFlattenFunc,
+		ExpandFunc:  workItemLinkingExpandFunc,
+		PolicyType:  branchpolicy.WorkItemLinking,
+	})
+}
+
+func workItemLinkingExpandFunc(d *schema.ResourceData, typeID uuid.UUID) (*policy.PolicyConfiguration, *string, error) {
+	return branchpolicy.BaseExpandFunc(d, typeID)
+}
+
+func workItemLinkingFlattenFunc(d *schema.ResourceData, policyConfig *policy.PolicyConfiguration, projectID *string) {
+	branchpolicy.BaseFlattenFunc(d, policyConfig, projectID)
+}